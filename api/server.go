@@ -0,0 +1,168 @@
+// Package api exposes the tracker's operations over HTTP/JSON, reusing
+// the same cli/db/stats functions the interactive panels call so both
+// front ends share one code path.
+package api
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/willyhwang50/TrackWholeFoodsOrders/app"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/cli"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/db"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/order"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/stats"
+)
+
+//go:embed static/dashboard.html
+var staticFS embed.FS
+
+// NewMux builds the HTTP routes for the tracker: POST /update, GET
+// /orders, GET /stats/pattern, GET /stats/predict/date and GET
+// /stats/predict/amount, plus a dashboard at /.
+func NewMux(a *app.App) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/update", handleUpdate(a))
+	mux.HandleFunc("/orders", handleOrders(a))
+	mux.HandleFunc("/stats/pattern", handlePattern(a))
+	mux.HandleFunc("/stats/predict/date", handlePredictDate(a))
+	mux.HandleFunc("/stats/predict/amount", handlePredictAmt(a))
+	mux.HandleFunc("/", handleDashboard)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeErr(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func handleUpdate(a *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeErr(w, http.StatusMethodNotAllowed, fmt.Errorf("POST required"))
+			return
+		}
+		if err := cli.UpdateDB(a); err != nil {
+			writeErr(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, map[string]string{"status": "ok"})
+	}
+}
+
+// condFromQuery builds Conditions from the ?start=&end=&min=&max=&limit=
+// query params shared by /orders and the /stats routes, defaulting any
+// that are omitted to CreateView's usual defaults.
+func condFromQuery(q url.Values) order.Conditions {
+	cond := order.Conditions{
+		Start:   "2021-01-01",
+		End:     "2100-01-01",
+		Lb:      "0",
+		Ub:      "100000",
+		Numrows: "100",
+	}
+	if v := q.Get("start"); v != "" {
+		cond.Start = v
+	}
+	if v := q.Get("end"); v != "" {
+		cond.End = v
+	}
+	if v := q.Get("min"); v != "" {
+		cond.Lb = v
+	}
+	if v := q.Get("max"); v != "" {
+		cond.Ub = v
+	}
+	if v := q.Get("limit"); v != "" {
+		cond.Numrows = v
+	}
+	return cond
+}
+
+func handleOrders(a *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cond := condFromQuery(r.URL.Query())
+		if _, _, err := cond.GetQuery(); err != nil {
+			writeErr(w, http.StatusBadRequest, err)
+			return
+		}
+		orders, err := db.RetrieveOrders(a.DB, &cond)
+		if err != nil {
+			writeErr(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, orders)
+	}
+}
+
+func handlePattern(a *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cond := condFromQuery(r.URL.Query())
+		if _, _, err := cond.GetQuery(); err != nil {
+			writeErr(w, http.StatusBadRequest, err)
+			return
+		}
+		summary, err := stats.ComputePattern(a.DB, &cond)
+		if err != nil {
+			writeErr(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, summary)
+	}
+}
+
+func handlePredictDate(a *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		total, err := strconv.ParseFloat(r.URL.Query().Get("total"), 64)
+		if err != nil {
+			writeErr(w, http.StatusBadRequest, fmt.Errorf("total must be a number: %w", err))
+			return
+		}
+		pred, err := stats.ComputeDatePrediction(a.DB, total)
+		if err != nil {
+			writeErr(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, pred)
+	}
+}
+
+func handlePredictAmt(a *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		if date == "" {
+			writeErr(w, http.StatusBadRequest, fmt.Errorf("date is required"))
+			return
+		}
+		pred, err := stats.ComputeAmtPrediction(a.DB, date)
+		if err != nil {
+			writeErr(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, pred)
+	}
+}
+
+func handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	page, err := staticFS.ReadFile("static/dashboard.html")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
@@ -0,0 +1,34 @@
+// Package app wires together the shared services (Gmail, the database,
+// config and logging) that every handler in cli/db/stats/gmail needs,
+// replacing the globals the original main.go relied on.
+package app
+
+import (
+	"database/sql"
+	"log"
+
+	gapi "google.golang.org/api/gmail/v1"
+
+	"github.com/willyhwang50/TrackWholeFoodsOrders/gmail"
+)
+
+// Config holds the runtime settings previously read ad hoc from the
+// environment inside main.go.
+type Config struct {
+	LastUpdate string
+}
+
+// App bundles the services a handler needs so they can be passed around
+// explicitly instead of relying on package-level globals.
+type App struct {
+	Gmail   *gapi.Service
+	DB      *sql.DB
+	Config  Config
+	Logger  *log.Logger
+	Sources []gmail.OrderSource
+}
+
+// New builds an App from its constituent services.
+func New(srv *gapi.Service, db *sql.DB, cfg Config, logger *log.Logger, sources []gmail.OrderSource) *App {
+	return &App{Gmail: srv, DB: db, Config: cfg, Logger: logger, Sources: sources}
+}
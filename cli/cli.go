@@ -0,0 +1,225 @@
+// Package cli implements the interactive, terminal-driven panels that make
+// up the tracker's menu system.
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/willyhwang50/TrackWholeFoodsOrders/app"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/db"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/gmail"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/order"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/stats"
+)
+
+//UpdateDB pulls new records after the lastupdate date from every
+//registered source and tags each row with the source that produced it.
+func UpdateDB(a *app.App) error {
+	for _, src := range a.Sources {
+		fmt.Println("Updating from source:", src.Name())
+		Orders, err := gmail.ReadData(a.Gmail, a.Config.LastUpdate, src)
+		if err != nil {
+			return fmt.Errorf("%s: cannot read data: %w", src.Name(), err)
+		}
+		if err := db.InsertOrder(Orders, a.DB); err != nil {
+			return fmt.Errorf("%s: cannot insert orders: %w", src.Name(), err)
+		}
+	}
+	fmt.Println("successfully uploaded data")
+	return nil
+}
+
+// printOrders prints each retrieved order's summary, or a notice if none
+// matched the given conditions.
+func printOrders(orders []order.Order) {
+	if len(orders) == 0 {
+		fmt.Println("No orders matched.")
+		return
+	}
+	for _, ord := range orders {
+		ord.GetSummary()
+	}
+}
+
+//CreateView collects conditions for desired data
+func CreateView(a *app.App) {
+	Condmap := map[int]bool{
+		1: false,
+		2: false,
+		3: false,
+	}
+	condInit := order.Conditions{
+		Start:   "2021-01-01",
+		End:     "2021-05-01",
+		Lb:      "0.0",
+		Ub:      "100000",
+		Numrows: "100",
+	}
+	cond := condInit
+	var cat int
+CondPanel:
+	for {
+		fmt.Println("Specify conditions for the data you want to view: ")
+		fmt.Println("Current Conditions are: ", cond.GetConditions())
+		fmt.Println("Add Conditions of...")
+		fmt.Println("1. Date")
+		fmt.Println("2. Total Amount")
+		fmt.Println("3. Number of Rows")
+		fmt.Println("4. Retrieve All data")
+		fmt.Println("5. Retrieve With Current Condition")
+		fmt.Println("6. Search Orders by Item (e.g. avocado, salmon)")
+		fmt.Println("7. Return to Main")
+		fmt.Scanln(&cat)
+		switch cat {
+		case 1:
+			if Condmap[1] {
+				var resp string
+				fmt.Println("Dates Already Specified. Do you want to Override? yes/no")
+				fmt.Scanln(&resp)
+				if resp == "no" {
+					continue
+				}
+			}
+			var start string
+			var end string
+			fmt.Println("Enter Starting Date: (format yyyy-mm-dd)")
+			fmt.Scanln(&start)
+			cond.Start = start
+			fmt.Println("Enter End Date: (format yyyy-mm-dd)")
+			fmt.Scanln(&end)
+			cond.End = end
+			Condmap[1] = true
+		case 2:
+			if Condmap[2] {
+				var resp string
+				fmt.Println("Total Amount Already Specified. Do you want to Override? yes/no")
+				fmt.Scanln(&resp)
+				if resp == "no" {
+					continue
+				}
+			}
+			var lb string
+			var ub string
+			fmt.Println("1. Greater Than")
+			fmt.Scanln(&lb)
+			cond.Lb = lb
+			fmt.Println("2. Less Than")
+			fmt.Scanln(&ub)
+			cond.Ub = ub
+			Condmap[2] = true
+		case 3:
+			if Condmap[3] {
+				var resp string
+				fmt.Println("Number of Rows Already Specified. Do you want to Override? yes/no")
+				fmt.Scanln(&resp)
+				if resp == "no" {
+					continue
+				}
+			}
+			var numrows string
+			fmt.Println("How Many Rows do you want?")
+			fmt.Scanln(&numrows)
+			cond.Numrows = numrows
+			Condmap[3] = true
+		case 4:
+			fmt.Println("Retrieving All data")
+			orders, err := db.RetrieveOrders(a.DB, &condInit)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			printOrders(*orders)
+		case 5:
+			fmt.Println("Retrieving Data with conditions: ", cond.GetConditions())
+			orders, err := db.RetrieveOrders(a.DB, &cond)
+			if err != nil {
+				fmt.Println(err)
+				break CondPanel
+			}
+			printOrders(*orders)
+			break CondPanel
+		case 6:
+			var raw string
+			fmt.Println("Enter item names to search for (space separated):")
+			fmt.Scanln(&raw)
+			terms := strings.Fields(raw)
+			fmt.Println("Searching for orders matching: ", terms)
+			orders, err := db.SearchOrders(a.DB, terms, &cond)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			printOrders(*orders)
+		case 7:
+			break CondPanel
+		default:
+			fmt.Println("Not a valid category")
+			continue
+		}
+	}
+	return
+}
+
+// RunUpdatePrompt asks the user whether to pull new order emails and,
+// if so, runs UpdateDB.
+func RunUpdatePrompt(a *app.App) {
+	fmt.Println("Your last update is on ", a.Config.LastUpdate)
+	fmt.Print("Do you want to update your database?: yes/no (lowercase)")
+	var update string
+	fmt.Scanln(&update)
+
+UpdateQ:
+	for {
+		switch update {
+		case "yes":
+			if err := UpdateDB(a); err != nil {
+				fmt.Println(err)
+				break UpdateQ
+			}
+			fmt.Println("Update is complete. Latest Update is now", a.Config.LastUpdate)
+			break UpdateQ
+		case "no":
+			fmt.Println("Not Updating Database. Latest Update is", a.Config.LastUpdate)
+			break UpdateQ
+		default:
+			fmt.Println("Not a proper command. Type 'yes' or 'no'")
+			fmt.Scanln(&update)
+		}
+	}
+}
+
+// RunControlPanel drives the top-level menu: view/edit/stats/quit.
+func RunControlPanel(a *app.App) {
+	fmt.Println("Directing you to Control Panel")
+	fmt.Println("...........................................................")
+
+	var action int
+ActionPanel:
+	for {
+		//Choose Action
+		fmt.Println("Choose Options: ")
+		fmt.Println("1: View Order Records")
+		fmt.Println("2: Edit Order Records")
+		fmt.Println("3: Get Stats")
+		fmt.Println("4: Quit")
+		fmt.Scanln(&action)
+		switch action {
+		case 1:
+			fmt.Println("Directing to View...")
+			CreateView(a)
+		case 2:
+			fmt.Println("Directing to Edit...")
+			//CreateEdit(a)
+		case 3:
+			fmt.Println("Directing to Stats...")
+			stats.CreateStats(a.DB)
+		case 4:
+			fmt.Println("Bye bye")
+			break ActionPanel
+		default:
+			fmt.Println("Not a valid choice")
+			continue
+		}
+	}
+}
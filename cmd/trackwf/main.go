@@ -0,0 +1,133 @@
+// Command trackwf is the entry point for the Whole Foods order tracker: it
+// wires up the Gmail client, the database connection and config/logging
+// into an app.App, then hands off to the interactive cli panels.
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	gapi "google.golang.org/api/gmail/v1"
+
+	"github.com/willyhwang50/TrackWholeFoodsOrders/api"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/app"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/cli"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/db"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/gmail"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/order"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/providers"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/providers/wholefoods"
+
+	"golang.org/x/oauth2/google"
+)
+
+// loadSources returns the built-in Whole Foods source plus any enabled
+// providers declared in sources.yaml.
+func loadSources(logger *log.Logger) []gmail.OrderSource {
+	sources := []gmail.OrderSource{wholefoods.New()}
+
+	cfgs, err := providers.LoadSources("sources.yaml")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Println("cannot load sources.yaml:", err)
+		}
+		return sources
+	}
+	for _, cfg := range cfgs {
+		if !cfg.Enabled {
+			continue
+		}
+		sources = append(sources, providers.FromConfig(cfg))
+	}
+	return sources
+}
+
+func main() {
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+
+	// Welcome Message
+	logger.Println("Welcome! Initiating...")
+
+	//Read Gmail Credientials
+	logger.Println("Reading Credentials...")
+	b, err := ioutil.ReadFile("credentials.json")
+	if err != nil {
+		logger.Fatalf("Unable to read client secret file: %v", err)
+	}
+	logger.Println("Creating Client...")
+
+	// Create a new client using credentials
+	config, err := google.ConfigFromJSON(b, gapi.GmailReadonlyScope)
+	if err != nil {
+		logger.Fatalf("Unable to parse client secret file to config: %v", err)
+	}
+	client := gmail.GetClient(config)
+
+	//instantiate a gmail service
+	logger.Println("Instantiating Gmail Service...")
+	srv, err := gapi.New(client)
+	if err != nil {
+		logger.Fatalf("Unable to retrieve Gmail client: %v", err)
+	}
+
+	//Get Environmental Variables
+	logger.Println("Loading Environmental Variables...")
+	godotenv.Load(".env")
+
+	//retrieve db password
+	password := os.Getenv("password")
+	if len(password) == 0 {
+		logger.Fatal("cannot load password")
+	}
+
+	//Instantiate Database Driver
+	logger.Println("Connecting to mySQL Database")
+	conn, err := db.Connect(password)
+	if err != nil {
+		logger.Fatal("cannot instantaite database driver", err)
+	}
+	if err := db.Migrate(conn); err != nil {
+		logger.Fatal("cannot migrate database schema", err)
+	}
+
+	//Check if Database should be Updated
+	LastUpdate, found := os.LookupEnv("LastUpdate")
+	if !found {
+		LastUpdate = "2021-Jan-01"
+		os.Setenv("LastUpdate", LastUpdate)
+	}
+	LastUpdateTime, err := time.Parse(order.ShortForm, LastUpdate)
+	if err != nil {
+		logger.Fatal("cannot parse lastupdate date to time")
+	}
+	TimeNow := time.Now()
+	if !LastUpdateTime.Equal(TimeNow) {
+		logger.Println("You have not updated your database for", TimeNow.Sub(LastUpdateTime).Hours(), "hours")
+	}
+
+	a := app.New(srv, conn, app.Config{LastUpdate: LastUpdate}, logger, loadSources(logger))
+
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServer(a, logger)
+		return
+	}
+
+	cli.RunUpdatePrompt(a)
+	cli.RunControlPanel(a)
+}
+
+// runServer exposes the tracker's operations over HTTP/JSON instead of
+// the interactive panels, reusing the same handlers via api.NewMux.
+func runServer(a *app.App, logger *log.Logger) {
+	addr := ":8080"
+	if p := os.Getenv("PORT"); p != "" {
+		addr = ":" + p
+	}
+	logger.Println("Serving HTTP API on", addr)
+	logger.Fatal(http.ListenAndServe(addr, api.NewMux(a)))
+}
@@ -0,0 +1,146 @@
+// Package db owns the mySQL connection and all reads/writes against the
+// WholeFoods table.
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/willyhwang50/TrackWholeFoodsOrders/order"
+)
+
+// Connect opens the mySQL connection used throughout the tracker. Run
+// Migrate against the returned *sql.DB before using it so WholeFoods and
+// its indexes exist.
+func Connect(password string) (*sql.DB, error) {
+	return sql.Open("mysql", "root:"+password+"@tcp(127.0.0.1:3306)/sys")
+}
+
+// InsertOrder to db, via a parameterized statement so order ids/subjects/
+// bodies pulled from email can't break out of the VALUES clause.
+func InsertOrder(Orders []order.Order, db *sql.DB) error {
+	const stmt = "INSERT INTO WholeFoods(order_id, order_date, grand_total, subject, body, source) VALUES (?, ?, ?, ?, ?, ?)"
+	for i, ord := range Orders {
+		_, err := db.Exec(stmt, ord.OrdNum, ord.OrdDate, ord.GrandTotal, ord.Subject, ord.Body, ord.Source)
+		if err != nil {
+			return fmt.Errorf("cannot add %d th row to database: %w", i, err)
+		}
+	}
+	return nil
+}
+
+//RetrieveOrders from mySQL Database.
+func RetrieveOrders(db *sql.DB, cond *order.Conditions) (*[]order.Order, error) {
+	var OrdData []order.Order
+	query, args, err := (*cond).GetQuery()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load data from mySQL database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ord order.Order
+		err = rows.Scan(&ord.OrdNum, &ord.OrdDate, &ord.GrandTotal, &ord.Subject, &ord.Body, &ord.Source)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve data: %w", err)
+		}
+		OrdData = append(OrdData, ord)
+	}
+	return &OrdData, nil
+}
+
+// GetRecentOrders returns up to n of the most recent orders, oldest
+// first, for use by the stats predictors.
+func GetRecentOrders(db *sql.DB, n int) ([]order.Order, error) {
+	rows, err := db.Query("SELECT order_id, order_date, grand_total FROM WholeFoods ORDER BY order_date DESC LIMIT ?", n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []order.Order
+	for rows.Next() {
+		var ord order.Order
+		if err := rows.Scan(&ord.OrdNum, &ord.OrdDate, &ord.GrandTotal); err != nil {
+			return nil, err
+		}
+		orders = append(orders, ord)
+	}
+	for i, j := 0, len(orders)-1; i < j; i, j = i+1, j-1 {
+		orders[i], orders[j] = orders[j], orders[i]
+	}
+	return orders, nil
+}
+
+// ftsReserved matches characters MySQL's boolean full-text mode treats as
+// operators (+ - > < ( ) ~ * " @). They're stripped from user search terms
+// so a stray character can't turn a term into an unintended operator.
+var ftsReserved = regexp.MustCompile(`[+\-><()~*"@]`)
+
+// EscapeFTSTerm strips FTS boolean-mode operator characters from a single
+// search term, leaving plain words safe to drop into MATCH ... AGAINST.
+func EscapeFTSTerm(term string) string {
+	return ftsReserved.ReplaceAllString(term, "")
+}
+
+// SearchOrders combines the existing date/amount Conditions with a
+// full-text search over the stored subject/body so orders can be found by
+// item name (e.g. "avocado", "salmon") instead of just id/date/total.
+// Terms are ANDed together with MySQL's boolean-mode MATCH ... AGAINST.
+// If terms is empty or whitespace-only, this falls back to the plain
+// condition-only query.
+func SearchOrders(db *sql.DB, terms []string, cond *order.Conditions) (*[]order.Order, error) {
+	clean := make([]string, 0, len(terms))
+	for _, t := range terms {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		clean = append(clean, EscapeFTSTerm(t))
+	}
+	if len(clean) == 0 {
+		return RetrieveOrders(db, cond)
+	}
+
+	boolQuery := "+" + strings.Join(clean, " +")
+	baseQuery, baseArgs, err := cond.GetQuery()
+	if err != nil {
+		return nil, err
+	}
+	matchClause := "MATCH(subject, body) AGAINST(? IN BOOLEAN MODE)"
+
+	var fullQuery string
+	var args []interface{}
+	if idx := strings.Index(baseQuery, " limit "); idx != -1 {
+		fullQuery = baseQuery[:idx] + " and " + matchClause + baseQuery[idx:]
+		args = append(append([]interface{}{}, baseArgs[:len(baseArgs)-1]...), boolQuery, baseArgs[len(baseArgs)-1])
+	} else {
+		fullQuery = baseQuery + " and " + matchClause
+		args = append(baseArgs, boolQuery)
+	}
+
+	var OrdData []order.Order
+	rows, err := db.Query(fullQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load data from mySQL database: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ord order.Order
+		err = rows.Scan(&ord.OrdNum, &ord.OrdDate, &ord.GrandTotal, &ord.Subject, &ord.Body, &ord.Source)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve data: %w", err)
+		}
+		OrdData = append(OrdData, ord)
+	}
+	return &OrdData, nil
+}
@@ -0,0 +1,20 @@
+package db
+
+import "testing"
+
+func TestEscapeFTSTerm(t *testing.T) {
+	cases := []struct {
+		term string
+		want string
+	}{
+		{"avocado", "avocado"},
+		{"+avocado", "avocado"},
+		{`sal"mon`, "salmon"},
+		{"(salmon)", "salmon"},
+	}
+	for _, c := range cases {
+		if got := EscapeFTSTerm(c.term); got != c.want {
+			t.Errorf("EscapeFTSTerm(%q) = %q, want %q", c.term, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// Migrate applies any not-yet-applied file under migrations/, in filename
+// order, tracking what's run in a schema_migrations table. This replaces
+// the old assumption that WholeFoods, its indexes and the FTS columns
+// already existed. The schema_migrations guard only covers re-runs against
+// a database the tracker itself migrated: a migration that ALTERs an
+// object (e.g. 0002's FULLTEXT INDEX, 0003's ADD COLUMN) will still error
+// out against a database that already had that object from outside this
+// tracker.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version VARCHAR(255) PRIMARY KEY,
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("cannot create schema_migrations table: %w", err)
+	}
+
+	entries, err := migrationFS.ReadDir("migrations")
+	if err != nil {
+		return fmt.Errorf("cannot read migrations: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var applied bool
+		row := db.QueryRow("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = ?)", name)
+		if err := row.Scan(&applied); err != nil {
+			return fmt.Errorf("cannot check migration %s: %w", name, err)
+		}
+		if applied {
+			continue
+		}
+
+		contents, err := migrationFS.ReadFile("migrations/" + name)
+		if err != nil {
+			return fmt.Errorf("cannot read migration %s: %w", name, err)
+		}
+		if _, err := db.Exec(string(contents)); err != nil {
+			return fmt.Errorf("cannot apply migration %s: %w", name, err)
+		}
+		if _, err := db.Exec("INSERT INTO schema_migrations(version) VALUES (?)", name); err != nil {
+			return fmt.Errorf("cannot record migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package gmail
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/willyhwang50/TrackWholeFoodsOrders/order"
+)
+
+// GetSubject pulls the Subject header off a raw Gmail message.
+func GetSubject(msg *gmail.Message) string {
+	for _, h := range msg.Payload.Headers {
+		if h.Name == "Subject" {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+// GetOrderFeats creates an array of Order structs by handing each
+// message's body to src's Parse. Messages that can't be fetched, decoded
+// or parsed are logged and skipped rather than aborting the whole batch.
+func GetOrderFeats(user string, srv *gmail.Service, r *gmail.ListMessagesResponse, src OrderSource) []order.Order {
+	Orders := []order.Order{}
+	for _, msg := range r.Messages {
+		RawMsg, err := srv.Users.Messages.Get(user, msg.Id).Do()
+		if err != nil {
+			log.Printf("%s: cannot retrieve message %s: %v", src.Name(), msg.Id, err)
+			continue
+		}
+		BodyMsg := RawMsg.Payload.Parts[0].Body.Data
+		StrBody, err := base64.URLEncoding.DecodeString(BodyMsg)
+		if err != nil {
+			log.Printf("%s: cannot decode message %s: %v", src.Name(), msg.Id, err)
+			continue
+		}
+		ord, err := src.Parse(StrBody)
+		if err != nil {
+			log.Printf("%s: cannot parse message %s: %v", src.Name(), msg.Id, err)
+			continue
+		}
+		ord.Subject = GetSubject(RawMsg)
+		ord.Body = string(StrBody)
+		fmt.Println(ord.OrdNum, ord.OrdDate, ord.GrandTotal)
+		Orders = append(Orders, ord)
+	}
+	return Orders
+}
+
+// buildSearchQuery forms a Gmail search query using lastupdate
+func buildSearchQuery(q string, lastupdate string) string {
+	re := regexp.MustCompile("-")
+	lastupdate = re.ReplaceAllString(lastupdate, "/")
+	re = regexp.MustCompile("[A-Za-z]+")
+	lastupdate = re.ReplaceAllString(lastupdate, order.Monthmap[lastupdate[5:8]])
+	NewQuery := q + " after:" + lastupdate
+	return NewQuery
+}
+
+// ReadData retrieves relevant Emails for the given source.
+func ReadData(srv *gmail.Service, lastupdate string, src OrderSource) ([]order.Order, error) {
+	const user = "me"
+	NewQuery := buildSearchQuery(src.Query(), lastupdate)
+	r, err := srv.Users.Messages.List(user).IncludeSpamTrash(false).MaxResults(10).Q(NewQuery).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve mails: %w", err)
+	}
+	fmt.Println("The length of the list is", len(r.Messages))
+
+	//extract features from emails
+	Orders := GetOrderFeats(user, srv, r, src)
+	if len(Orders) == 0 {
+		log.Printf("%s: no orders found", src.Name())
+		return Orders, nil
+	}
+
+	//Save Newly extracted Data as temporary json file
+	Ordfile, err := json.Marshal(Orders)
+	if err != nil {
+		log.Printf("%s: can't marshal orders to json cache: %v", src.Name(), err)
+		return Orders, nil
+	}
+	ioutil.WriteFile(src.Name()+"_orders.json", Ordfile, os.ModePerm)
+
+	return Orders, nil
+}
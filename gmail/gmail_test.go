@@ -0,0 +1,11 @@
+package gmail
+
+import "testing"
+
+func TestBuildSearchQuery(t *testing.T) {
+	got := buildSearchQuery("from:receipts@wholefoods.com", "2021-Jul-15")
+	want := "from:receipts@wholefoods.com after:2021/07/15"
+	if got != want {
+		t.Errorf("buildSearchQuery() = %q, want %q", got, want)
+	}
+}
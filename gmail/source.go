@@ -0,0 +1,18 @@
+package gmail
+
+import "github.com/willyhwang50/TrackWholeFoodsOrders/order"
+
+// OrderSource lets new order-email providers (Instacart, Uber Eats, plain
+// Amazon.com, ...) be registered without editing ReadData/GetOrderFeats:
+// each provider supplies its own Gmail search query and knows how to turn
+// a raw message body into an order.Order.
+type OrderSource interface {
+	// Name identifies the source; it's stored in the `source` column so
+	// rows can be traced back to the provider that produced them.
+	Name() string
+	// Query returns the Gmail search query used to find this source's
+	// order confirmation emails.
+	Query() string
+	// Parse extracts an order.Order from a raw (base64-decoded) message body.
+	Parse(rawBody []byte) (order.Order, error)
+}
@@ -0,0 +1,164 @@
+// Package order holds the Order and Conditions types shared by the db,
+// gmail, stats and cli packages, along with the date helpers used to move
+// between Gmail's date formats and the `yyyy-mm-dd` form stored in SQL.
+package order
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LongForm, ShortForm and DateLayout are the date layouts used throughout
+// the tracker: LongForm matches how Gmail prints a delivery time, ShortForm
+// is the abbreviated-month form LastUpdate is configured in, and DateLayout
+// is the numeric `yyyy-mm-dd` form ConvtoTime produces, OrdDate/the SQL DATE
+// column store, and the CLI/API targetDate and start/end params are
+// documented in.
+const (
+	LongForm   = "Jan 2, 2006 at 3:04pm (MST)"
+	ShortForm  = "2006-Jan-02"
+	DateLayout = "2006-01-02"
+)
+
+// Monthmap connects abbreviated name of month to corresponding int
+var Monthmap = map[string]string{
+	"Jan": "01",
+	"Feb": "02",
+	"Mar": "03",
+	"Apr": "04",
+	"May": "05",
+	"Jun": "06",
+	"Jul": "07",
+	"Aug": "08",
+	"Sep": "09",
+	"Oct": "10",
+	"Nov": "11",
+	"Dec": "12",
+}
+
+// ConvtoTime casts dates written in strings to the `yyyy-mm-dd` form
+// stored in OrdDate. An error is returned instead of crashing the process
+// so a single malformed date (e.g. from a provider's AnchorSource.Parse)
+// can be skipped rather than taking down the whole update.
+func ConvtoTime(t string) (string, error) {
+	rawtime := strings.Fields(t)[1:]
+	month := rawtime[0][:3]
+	day := strings.Trim(rawtime[1], ",")
+	dayint, err := strconv.Atoi(day)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert day to int: %w", err)
+	}
+	if dayint < 10 {
+		day = "0" + day
+	}
+	year := rawtime[2]
+	shortdate := year + "-" + Monthmap[month] + "-" + day
+	return shortdate, nil
+}
+
+// Order TYPE has properties ID, Date, Total.
+// Subject and Body are kept so order emails can be located later via
+// full-text search (see db.SearchOrders) even though the rest of the
+// schema only indexes id/date/total.
+type Order struct {
+	OrdNum     string  `json:"order_id"`
+	OrdDate    string  `json:"order_date"`
+	GrandTotal float64 `json:"grand_total"`
+	Subject    string  `json:"subject"`
+	Body       string  `json:"body"`
+	Source     string  `json:"source"`
+}
+
+//GetSummary of the properties.
+func (order Order) GetSummary() {
+	fmt.Println(order.OrdNum, order.OrdDate, order.GrandTotal)
+}
+
+// GetOrdDate changes OrdDate in Stringform to time.Time
+func (order Order) GetOrdDate() time.Time {
+	date, err := time.Parse(DateLayout, order.OrdDate)
+	if err != nil {
+		log.Fatal("cannot convert OrdDate to time.Time", err)
+	}
+	return date
+}
+
+// Conditions is a struct which contains information for writing a select query
+type Conditions struct {
+	Start   string
+	End     string
+	Lb      string
+	Ub      string
+	Numrows string
+}
+
+// GetNumRows retrieves the property Numrows as an int.
+func (c Conditions) GetNumRows() (int, error) {
+	nr, err := strconv.Atoi(c.Numrows)
+	if err != nil {
+		return 0, fmt.Errorf("cannot retrieve numrows as int: %w", err)
+	}
+	return nr, nil
+}
+
+// GetLowerBound retrieves the property Lb as a float64.
+func (c Conditions) GetLowerBound() (float64, error) {
+	lb, err := strconv.ParseFloat(c.Lb, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot retrieve lb as float64: %w", err)
+	}
+	return lb, nil
+}
+
+// GetUpperBound retrieves the property Ub as a float64.
+func (c Conditions) GetUpperBound() (float64, error) {
+	ub, err := strconv.ParseFloat(c.Ub, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot retrieve ub as float64: %w", err)
+	}
+	return ub, nil
+}
+
+// GetConditions retrieves summary of the conditions as a string
+func (c Conditions) GetConditions() string {
+	cond := "Date: " + c.Start + "~" + c.End + "/ "
+	cond += "Total amount: " + c.Lb + " ~ " + c.Ub + "/ "
+	cond += "Number of Rows: " + c.Numrows + "/ "
+	return cond
+}
+
+// GetQuery builds a parameterized Query (and its bind args, in order) for
+// retrieving data from mySQL. Callers pass the returned args to db.Query
+// alongside Query so no user-controlled value is concatenated into SQL. An
+// error is returned if Lb, Ub or Numrows aren't the numbers they claim to
+// be, so a malformed condition (e.g. from a query param) never reaches SQL.
+func (c Conditions) GetQuery() (string, []interface{}, error) {
+	lb, err := c.GetLowerBound()
+	if err != nil {
+		return "", nil, err
+	}
+	ub, err := c.GetUpperBound()
+	if err != nil {
+		return "", nil, err
+	}
+	nr, err := c.GetNumRows()
+	if err != nil {
+		return "", nil, err
+	}
+	Query := "SELECT order_id, order_date, grand_total, subject, body, source from WholeFoods where ? < order_date and order_date < ? and ? < grand_total and grand_total < ? limit ?"
+	args := []interface{}{c.Start, c.End, lb, ub, nr}
+	return Query, args, nil
+}
+
+// GetSumQuery writes a parameterized Query that retrieves summary statistics.
+func (c Conditions) GetSumQuery() (string, []interface{}, error) {
+	subquery, args, err := c.GetQuery()
+	if err != nil {
+		return "", nil, err
+	}
+	Query := "SELECT DATEDIFF(max(t1.order_date), min(t1.order_date)) as gap, avg(t1.grand_total) as spending from (" + subquery + ") t1;"
+	return Query, args, nil
+}
@@ -0,0 +1,37 @@
+package order
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvtoTime(t *testing.T) {
+	got, err := ConvtoTime("Thu Jul 15, 2021 at 3:04pm (MST)")
+	if err != nil {
+		t.Fatalf("ConvtoTime() error = %v", err)
+	}
+	want := "2021-07-15"
+	if got != want {
+		t.Errorf("ConvtoTime() = %q, want %q", got, want)
+	}
+}
+
+func TestConvtoTimeBadDay(t *testing.T) {
+	if _, err := ConvtoTime("Thu Jul abc, 2021 at 3:04pm (MST)"); err == nil {
+		t.Error("ConvtoTime() error = nil, want an error for a non-numeric day")
+	}
+}
+
+func TestGetOrdDateRoundTrip(t *testing.T) {
+	shortdate, err := ConvtoTime("Thu Jan 5, 2021 at 3:04pm (MST)")
+	if err != nil {
+		t.Fatalf("ConvtoTime() error = %v", err)
+	}
+	ord := Order{OrdDate: shortdate}
+
+	got := ord.GetOrdDate()
+	want := time.Date(2021, time.January, 5, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("GetOrdDate() = %v, want %v", got, want)
+	}
+}
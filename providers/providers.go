@@ -0,0 +1,130 @@
+// Package providers implements gmail.OrderSource for merchants whose
+// order emails can be described declaratively: an anchor phrase precedes
+// the order id, the delivery time and the grand total, the same shape the
+// original Whole Foods extractor assumed. New merchants can be added via
+// sources.yaml instead of Go code as long as their emails fit that shape.
+package providers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/willyhwang50/TrackWholeFoodsOrders/order"
+)
+
+// SourceConfig declares a merchant's Gmail search query and the anchor
+// phrases that mark the order id/delivery time/grand total in its order
+// emails.
+type SourceConfig struct {
+	Name    string `yaml:"name"`
+	Enabled bool   `yaml:"enabled"`
+	Query   string `yaml:"query"`
+	Anchors struct {
+		OrderID      string `yaml:"order_id"`
+		DeliveryTime string `yaml:"delivery_time"`
+		GrandTotal   string `yaml:"grand_total"`
+	} `yaml:"anchors"`
+}
+
+type sourcesFile struct {
+	Sources []SourceConfig `yaml:"sources"`
+}
+
+// LoadSources reads the merchant -> anchor-pattern mappings from a
+// sources.yaml config file.
+func LoadSources(path string) ([]SourceConfig, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f sourcesFile
+	if err := yaml.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	return f.Sources, nil
+}
+
+// AnchorSource is a gmail.OrderSource driven by anchor phrases: it scans
+// the whitespace-tokenized email body for each anchor and reads the
+// tokens that follow it.
+type AnchorSource struct {
+	SourceName         string
+	SearchQuery        string
+	OrderIDAnchor      string
+	DeliveryTimeAnchor string
+	GrandTotalAnchor   string
+}
+
+// FromConfig builds an AnchorSource from a declaratively-configured SourceConfig.
+func FromConfig(cfg SourceConfig) AnchorSource {
+	return AnchorSource{
+		SourceName:         cfg.Name,
+		SearchQuery:        cfg.Query,
+		OrderIDAnchor:      cfg.Anchors.OrderID,
+		DeliveryTimeAnchor: cfg.Anchors.DeliveryTime,
+		GrandTotalAnchor:   cfg.Anchors.GrandTotal,
+	}
+}
+
+// Name identifies the source; it's stored in the `source` column.
+func (s AnchorSource) Name() string { return s.SourceName }
+
+// Query returns the Gmail search query used to find this source's emails.
+func (s AnchorSource) Query() string { return s.SearchQuery }
+
+// anchorMatch reports whether anchor (e.g. "Order ID") appears in info
+// starting at i, tokenizing anchor the same way info was tokenized so
+// single-word anchors (e.g. "Arrived") match just as well as multi-word
+// ones (e.g. "Delivered at").
+func anchorMatch(info []string, i int, anchor string) (end int, ok bool) {
+	fields := strings.Fields(anchor)
+	if i+len(fields) > len(info) {
+		return 0, false
+	}
+	if strings.Join(info[i:i+len(fields)], " ") != anchor {
+		return 0, false
+	}
+	return i + len(fields), true
+}
+
+// Parse finds the order id, delivery date and grand total anchored in the
+// raw email body.
+func (s AnchorSource) Parse(rawBody []byte) (order.Order, error) {
+	info := strings.Fields(string(rawBody))
+	var id, date string
+	var tot float64
+	var idFound, dtFound, totFound bool
+	for i := 0; i < len(info)-20; i++ {
+		if end, ok := anchorMatch(info, i, s.DeliveryTimeAnchor); !dtFound && ok {
+			rawdate := strings.Join(info[end:end+4], " ")
+			d, err := order.ConvtoTime(rawdate)
+			if err != nil {
+				return order.Order{}, fmt.Errorf("cannot parse delivery time: %w", err)
+			}
+			date = d
+			dtFound = true
+		} else if end, ok := anchorMatch(info, i, s.GrandTotalAnchor); !totFound && ok {
+			trim := strings.Trim(info[end], "$")
+			total, err := strconv.ParseFloat(trim, 64)
+			if err != nil {
+				return order.Order{}, fmt.Errorf("cannot convert grand total to float: %w", err)
+			}
+			if total <= 0 {
+				return order.Order{}, fmt.Errorf("grand total must be positive, got %v", total)
+			}
+			tot = total
+			totFound = true
+		} else if end, ok := anchorMatch(info, i, s.OrderIDAnchor); !idFound && ok {
+			id = info[end]
+			idFound = true
+		}
+	}
+	if !idFound || !dtFound || !totFound {
+		return order.Order{}, fmt.Errorf("%s: could not find order id/delivery time/grand total in body", s.SourceName)
+	}
+	return order.Order{OrdNum: id, OrdDate: date, GrandTotal: tot, Source: s.SourceName}, nil
+}
@@ -0,0 +1,84 @@
+package providers
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAnchorSourceParse(t *testing.T) {
+	src := AnchorSource{
+		SourceName:         "testmerchant",
+		OrderIDAnchor:      "Order ID",
+		DeliveryTimeAnchor: "Delivery Time",
+		GrandTotalAnchor:   "Grand Total",
+	}
+
+	tokens := []string{"Order", "ID", "12345", "Delivery", "Time", "on", "Jul", "15,", "2021", "Grand", "Total", "$45.67"}
+	for i := 0; i < 28; i++ {
+		tokens = append(tokens, "padding"+strconv.Itoa(i))
+	}
+	body := strings.Join(tokens, " ")
+
+	ord, err := src.Parse([]byte(body))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if ord.OrdNum != "12345" {
+		t.Errorf("OrdNum = %q, want %q", ord.OrdNum, "12345")
+	}
+	if ord.OrdDate != "2021-07-15" {
+		t.Errorf("OrdDate = %q, want %q", ord.OrdDate, "2021-07-15")
+	}
+	if ord.GrandTotal != 45.67 {
+		t.Errorf("GrandTotal = %v, want %v", ord.GrandTotal, 45.67)
+	}
+	if ord.Source != "testmerchant" {
+		t.Errorf("Source = %q, want %q", ord.Source, "testmerchant")
+	}
+}
+
+func TestAnchorSourceParseSingleWordAnchors(t *testing.T) {
+	// Mirrors sources.yaml's ubereats/amazon entries, whose delivery_time
+	// ("Arrived") and grand_total ("Total:") anchors are a single token.
+	src := AnchorSource{
+		SourceName:         "amazon",
+		OrderIDAnchor:      "Order #",
+		DeliveryTimeAnchor: "Arrived",
+		GrandTotalAnchor:   "Total:",
+	}
+
+	tokens := []string{"Order", "#", "98765", "Arrived", "on", "Jul", "15,", "2021", "Total:", "$12.34"}
+	for i := 0; i < 28; i++ {
+		tokens = append(tokens, "padding"+strconv.Itoa(i))
+	}
+	body := strings.Join(tokens, " ")
+
+	ord, err := src.Parse([]byte(body))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if ord.OrdNum != "98765" {
+		t.Errorf("OrdNum = %q, want %q", ord.OrdNum, "98765")
+	}
+	if ord.OrdDate != "2021-07-15" {
+		t.Errorf("OrdDate = %q, want %q", ord.OrdDate, "2021-07-15")
+	}
+	if ord.GrandTotal != 12.34 {
+		t.Errorf("GrandTotal = %v, want %v", ord.GrandTotal, 12.34)
+	}
+}
+
+func TestAnchorSourceParseMissingAnchor(t *testing.T) {
+	src := AnchorSource{
+		SourceName:         "testmerchant",
+		OrderIDAnchor:      "Order ID",
+		DeliveryTimeAnchor: "Delivery Time",
+		GrandTotalAnchor:   "Grand Total",
+	}
+
+	body := strings.Repeat("padding ", 25)
+	if _, err := src.Parse([]byte(body)); err == nil {
+		t.Error("Parse() error = nil, want an error when no anchors are present")
+	}
+}
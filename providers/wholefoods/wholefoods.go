@@ -0,0 +1,27 @@
+// Package wholefoods provides the built-in gmail.OrderSource for Whole
+// Foods / Amazon delivery-confirmation emails.
+package wholefoods
+
+import "github.com/willyhwang50/TrackWholeFoodsOrders/providers"
+
+// Indicators for extracting features from Whole Foods / Amazon delivery emails.
+const (
+	GrandTotal   = "Grand total:"
+	DeliveryTime = "delivery time:"
+	OrderID      = "Details Order"
+)
+
+const searchQuery = "{from:order-update@amazon.com} 'your delivery is complete' 'Grand total'"
+
+// New returns the built-in Whole Foods / Amazon order source. Unlike
+// providers added through sources.yaml, it's always registered since it's
+// what the tracker was originally built for.
+func New() providers.AnchorSource {
+	return providers.AnchorSource{
+		SourceName:         "wholefoods",
+		SearchQuery:        searchQuery,
+		OrderIDAnchor:      OrderID,
+		DeliveryTimeAnchor: DeliveryTime,
+		GrandTotalAnchor:   GrandTotal,
+	}
+}
@@ -0,0 +1,307 @@
+// Package stats summarizes purchase history and will host the order
+// predictors described in CreateStats.
+package stats
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	store "github.com/willyhwang50/TrackWholeFoodsOrders/db"
+	"github.com/willyhwang50/TrackWholeFoodsOrders/order"
+)
+
+// recentOrderLimit bounds how much order history the predictors pull.
+const recentOrderLimit = 30
+
+// ewmaAlpha is the smoothing factor for the exponentially-weighted moving
+// average offered alongside the linear-regression predictions, for users
+// whose ordering habits are drifting rather than steady.
+const ewmaAlpha = 0.3
+
+// PatternSummary is the purchase-pattern summary computed by ComputePattern,
+// shared by the interactive ShowPattern panel and the /stats/pattern API route.
+type PatternSummary struct {
+	AvgGapDays int     `json:"avg_gap_days"`
+	AvgSpend   float64 `json:"avg_spend"`
+}
+
+// ComputePattern summarizes purchase patterns for cond's date/amount range.
+func ComputePattern(db *sql.DB, cond *order.Conditions) (PatternSummary, error) {
+	SumQuery, args, err := cond.GetSumQuery()
+	if err != nil {
+		return PatternSummary{}, err
+	}
+	rows, err := db.Query(SumQuery, args...)
+	if err != nil {
+		return PatternSummary{}, err
+	}
+	defer rows.Close()
+
+	var gap int
+	var spending float64
+	for rows.Next() {
+		if err := rows.Scan(&gap, &spending); err != nil {
+			return PatternSummary{}, err
+		}
+	}
+	numrows, err := cond.GetNumRows()
+	if err != nil {
+		return PatternSummary{}, err
+	}
+	if numrows == 0 {
+		return PatternSummary{}, fmt.Errorf("numrows must be greater than 0")
+	}
+	return PatternSummary{AvgGapDays: gap / numrows, AvgSpend: spending}, nil
+}
+
+//ShowPattern summarizes purchase patterns
+func ShowPattern(db *sql.DB, cond *order.Conditions) {
+	summary, err := ComputePattern(db, cond)
+	if err != nil {
+		log.Fatal("cannot get summary data", err)
+	}
+	fmt.Printf("You are purchasing every %d days \n", summary.AvgGapDays)
+	fmt.Printf("You are spending about %f $s per order \n", summary.AvgSpend)
+}
+
+// mean returns the arithmetic mean of xs.
+func mean(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// variance returns the population variance of xs.
+func variance(xs []float64) float64 {
+	m := mean(xs)
+	var sum float64
+	for _, x := range xs {
+		d := x - m
+		sum += d * d
+	}
+	return sum / float64(len(xs))
+}
+
+// stddev returns the population standard deviation of xs around its own
+// mean, used as a 1-sigma confidence interval around a prediction.
+func stddev(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	return math.Sqrt(variance(xs))
+}
+
+// ewma computes an exponentially-weighted moving average of xs with
+// smoothing factor alpha, as a drift-aware alternative to the linear fit.
+func ewma(xs []float64, alpha float64) float64 {
+	avg := xs[0]
+	for _, x := range xs[1:] {
+		avg = alpha*x + (1-alpha)*avg
+	}
+	return avg
+}
+
+// linregress fits y = intercept + slope*x by ordinary least squares and
+// returns the residuals (y - predicted) alongside the fitted parameters.
+func linregress(xs, ys []float64) (slope, intercept float64, residuals []float64) {
+	meanX := mean(xs)
+	meanY := mean(ys)
+
+	var num, den float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		dy := ys[i] - meanY
+		num += dx * dy
+		den += dx * dx
+	}
+	if den != 0 {
+		slope = num / den
+	}
+	intercept = meanY - slope*meanX
+
+	residuals = make([]float64, len(xs))
+	for i := range xs {
+		residuals[i] = ys[i] - (intercept + slope*xs[i])
+	}
+	return slope, intercept, residuals
+}
+
+// gapsAndLeadingTotals returns, for orders sorted oldest-first, the
+// inter-arrival gap in days between each consecutive pair together with
+// the grand_total of the order that precedes the gap.
+func gapsAndLeadingTotals(orders []order.Order) (gaps, leadingTotals []float64) {
+	gaps = make([]float64, 0, len(orders)-1)
+	leadingTotals = make([]float64, 0, len(orders)-1)
+	for i := 1; i < len(orders); i++ {
+		gap := orders[i].GetOrdDate().Sub(orders[i-1].GetOrdDate()).Hours() / 24
+		gaps = append(gaps, gap)
+		leadingTotals = append(leadingTotals, orders[i-1].GrandTotal)
+	}
+	return gaps, leadingTotals
+}
+
+// DatePrediction is the result computed by ComputeDatePrediction, shared
+// by the interactive PredictDate panel and the /stats/predict/date API route.
+type DatePrediction struct {
+	PredictedDate string  `json:"predicted_date"`
+	SigmaDays     float64 `json:"sigma_days"`
+	EWMADate      string  `json:"ewma_date"`
+}
+
+// ComputeDatePrediction estimates the date of the next order given an
+// expected targetTotal, by regressing the gap (in days) between
+// consecutive orders on the grand_total of the order that started each
+// gap. Falls back to the mean gap when totals don't vary enough to fit a
+// slope, and errors out below 3 rows of history.
+func ComputeDatePrediction(db *sql.DB, targetTotal float64) (DatePrediction, error) {
+	orders, err := store.GetRecentOrders(db, recentOrderLimit)
+	if err != nil {
+		return DatePrediction{}, fmt.Errorf("cannot load order history: %w", err)
+	}
+	if len(orders) < 3 {
+		return DatePrediction{}, fmt.Errorf("insufficient history")
+	}
+
+	gaps, totals := gapsAndLeadingTotals(orders)
+	lastDate := orders[len(orders)-1].GetOrdDate()
+	meanGap := mean(gaps)
+
+	var predictedGap, sigma float64
+	if variance(totals) < 1e-9 {
+		predictedGap = meanGap
+		sigma = stddev(gaps)
+	} else {
+		slope, intercept, residuals := linregress(totals, gaps)
+		predictedGap = intercept + slope*targetTotal
+		sigma = stddev(residuals)
+	}
+
+	predictedDate := lastDate.AddDate(0, 0, int(math.Round(predictedGap)))
+	ewmaDate := lastDate.AddDate(0, 0, int(math.Round(ewma(gaps, ewmaAlpha))))
+	return DatePrediction{
+		PredictedDate: predictedDate.Format(order.DateLayout),
+		SigmaDays:     sigma,
+		EWMADate:      ewmaDate.Format(order.DateLayout),
+	}, nil
+}
+
+// PredictDate prints the prediction computed by ComputeDatePrediction.
+func PredictDate(db *sql.DB, targetTotal float64) {
+	pred, err := ComputeDatePrediction(db, targetTotal)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Predicted next order date: %s (+/- %.1f days, 1 sigma)\n", pred.PredictedDate, pred.SigmaDays)
+	fmt.Printf("EWMA alternative (alpha=%.2f): %s\n", ewmaAlpha, pred.EWMADate)
+}
+
+// AmtPrediction is the result computed by ComputeAmtPrediction, shared by
+// the interactive PredictAmt panel and the /stats/predict/amount API route.
+type AmtPrediction struct {
+	PredictedTotal float64 `json:"predicted_total"`
+	Sigma          float64 `json:"sigma"`
+	EWMATotal      float64 `json:"ewma_total"`
+}
+
+// ComputeAmtPrediction estimates spend for targetDate by regressing
+// grand_total on the gap (in days) since the previous order. Falls back
+// to the mean total when gaps don't vary enough to fit a slope, and
+// errors out below 3 rows of history.
+func ComputeAmtPrediction(db *sql.DB, targetDate string) (AmtPrediction, error) {
+	orders, err := store.GetRecentOrders(db, recentOrderLimit)
+	if err != nil {
+		return AmtPrediction{}, fmt.Errorf("cannot load order history: %w", err)
+	}
+	if len(orders) < 3 {
+		return AmtPrediction{}, fmt.Errorf("insufficient history")
+	}
+
+	target, err := time.Parse(order.DateLayout, targetDate)
+	if err != nil {
+		return AmtPrediction{}, fmt.Errorf("cannot parse target date: %w", err)
+	}
+
+	gaps := make([]float64, 0, len(orders)-1)
+	totals := make([]float64, 0, len(orders)-1)
+	for i := 1; i < len(orders); i++ {
+		gaps = append(gaps, orders[i].GetOrdDate().Sub(orders[i-1].GetOrdDate()).Hours()/24)
+		totals = append(totals, orders[i].GrandTotal)
+	}
+
+	lastDate := orders[len(orders)-1].GetOrdDate()
+	targetGap := target.Sub(lastDate).Hours() / 24
+	meanTotal := mean(totals)
+
+	var predictedTotal, sigma float64
+	if variance(gaps) < 1e-9 {
+		predictedTotal = meanTotal
+		sigma = stddev(totals)
+	} else {
+		slope, intercept, residuals := linregress(gaps, totals)
+		predictedTotal = intercept + slope*targetGap
+		sigma = stddev(residuals)
+	}
+
+	return AmtPrediction{PredictedTotal: predictedTotal, Sigma: sigma, EWMATotal: ewma(totals, ewmaAlpha)}, nil
+}
+
+// PredictAmt prints the prediction computed by ComputeAmtPrediction.
+func PredictAmt(db *sql.DB, targetDate string) {
+	pred, err := ComputeAmtPrediction(db, targetDate)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("Predicted spend on %s: $%.2f (+/- $%.2f, 1 sigma)\n", targetDate, pred.PredictedTotal, pred.Sigma)
+	fmt.Printf("EWMA alternative (alpha=%.2f): $%.2f\n", ewmaAlpha, pred.EWMATotal)
+}
+
+// CreateStats based on the Database.
+func CreateStats(db *sql.DB) {
+	CondStats := order.Conditions{
+		Start:   "2021-01-01",
+		End:     "2021-05-01",
+		Lb:      "0.0",
+		Ub:      "100000",
+		Numrows: "7",
+	}
+	fmt.Println("What do you want to do?")
+	fmt.Println("1. Summarize Purchase Pattern")
+	fmt.Println("2. Predict next order date. (amount fixed)")
+	fmt.Println("3. Predict how much I should order (date fixed)")
+	fmt.Println("4. Return to main menu")
+	var stats int
+	fmt.Scanln(&stats)
+StatsQuery:
+	for {
+		switch stats {
+		case 1:
+			ShowPattern(db, &CondStats)
+			break StatsQuery
+		case 2:
+			var targetTotal float64
+			fmt.Println("Enter the expected order amount:")
+			fmt.Scanln(&targetTotal)
+			PredictDate(db, targetTotal)
+			break StatsQuery
+		case 3:
+			var targetDate string
+			fmt.Println("Enter the target date (format yyyy-mm-dd):")
+			fmt.Scanln(&targetDate)
+			PredictAmt(db, targetDate)
+			break StatsQuery
+		case 4:
+			break StatsQuery
+		default:
+			fmt.Println("Not a Valid input. Try again.")
+			fmt.Scanln(&stats)
+		}
+	}
+
+}
@@ -0,0 +1,29 @@
+package stats
+
+import "testing"
+
+func TestLinregress(t *testing.T) {
+	xs := []float64{1, 2, 3, 4}
+	ys := []float64{2, 4, 6, 8}
+
+	slope, intercept, residuals := linregress(xs, ys)
+	if d := slope - 2; d < -1e-9 || d > 1e-9 {
+		t.Errorf("slope = %v, want 2", slope)
+	}
+	if intercept < -1e-9 || intercept > 1e-9 {
+		t.Errorf("intercept = %v, want 0", intercept)
+	}
+	for i, r := range residuals {
+		if r < -1e-9 || r > 1e-9 {
+			t.Errorf("residuals[%d] = %v, want 0 for a perfect fit", i, r)
+		}
+	}
+}
+
+func TestEwma(t *testing.T) {
+	got := ewma([]float64{10, 10, 10}, 0.5)
+	want := 10.0
+	if got < want-1e-9 || got > want+1e-9 {
+		t.Errorf("ewma() = %v, want %v", got, want)
+	}
+}